@@ -0,0 +1,94 @@
+package graphqltogo
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffPolicy controls the delay between successive dial/reconnect
+// attempts. See WithReconnectBackoff.
+type BackoffPolicy struct {
+	Min    time.Duration
+	Max    time.Duration
+	Factor float64
+	Jitter bool
+}
+
+// defaultBackoffPolicy mirrors the client's previous fixed 2s retry delay
+// as a floor, growing up to 30s.
+var defaultBackoffPolicy = BackoffPolicy{
+	Min:    2 * time.Second,
+	Max:    30 * time.Second,
+	Factor: 2,
+	Jitter: true,
+}
+
+// duration computes the delay to use before the given 0-based attempt
+// number, applying full jitter (a random duration in [0, delay]) when
+// enabled to avoid a thundering herd of clients retrying in lockstep.
+func (b BackoffPolicy) duration(attempt int) time.Duration {
+	min := b.Min
+	if min <= 0 {
+		min = defaultBackoffPolicy.Min
+	}
+	max := b.Max
+	if max <= 0 {
+		max = defaultBackoffPolicy.Max
+	}
+	factor := b.Factor
+	if factor <= 1 {
+		factor = defaultBackoffPolicy.Factor
+	}
+
+	delay := float64(min) * math.Pow(factor, float64(attempt))
+	if delay > float64(max) {
+		delay = float64(max)
+	}
+	if b.Jitter {
+		delay = rand.Float64() * delay
+	}
+	return time.Duration(delay)
+}
+
+// ReconnectEvent is emitted to a WithReconnectListener on every
+// reconnect/dial attempt.
+type ReconnectEvent struct {
+	Attempt int
+	Err     error
+}
+
+// WithReconnectBackoff configures the exponential backoff used between
+// dial and reconnect attempts. factor must be > 1; jitter enables full
+// jitter (a random delay in [0, computed delay]) to avoid a thundering herd
+// of clients retrying a recovering server in lockstep.
+func WithReconnectBackoff(min, max time.Duration, factor float64, jitter bool) ClientOption {
+	return func(client *GraphQLClient) {
+		client.backoff = BackoffPolicy{Min: min, Max: max, Factor: factor, Jitter: jitter}
+	}
+}
+
+// WithMaxReconnectDuration caps the total time spent retrying a dial or
+// reconnect before giving up. Zero (the default) retries indefinitely.
+func WithMaxReconnectDuration(d time.Duration) ClientOption {
+	return func(client *GraphQLClient) {
+		client.maxReconnectDuration = d
+	}
+}
+
+// WithReconnectListener registers a callback invoked with a ReconnectEvent
+// on every dial/reconnect attempt, successful or not.
+func WithReconnectListener(listener func(ReconnectEvent)) ClientOption {
+	return func(client *GraphQLClient) {
+		client.reconnectListener = listener
+	}
+}
+
+// WithOnGiveUp registers a callback invoked once, with the last error seen,
+// when the reconnect budget set by WithMaxReconnectDuration is exhausted.
+// Without this, a client that can't reconnect simply stays disconnected.
+func WithOnGiveUp(onGiveUp func(err error)) ClientOption {
+	return func(client *GraphQLClient) {
+		client.onGiveUp = onGiveUp
+	}
+}
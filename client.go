@@ -2,13 +2,13 @@ package graphqltogo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"sync"
-
-	"github.com/gorilla/websocket"
+	"time"
 )
 
 type GraphQLResponse[T interface{}] struct {
@@ -18,23 +18,43 @@ type GraphQLResponse[T interface{}] struct {
 
 type subscription struct {
 	Channel   chan interface{}
+	ErrChan   chan *SubscriptionError
 	Query     string
 	Variables map[string]interface{}
 	NewTarget func() interface{}
 }
 
 type GraphQLClient struct {
-	httpEndpoint     string
-	wsEndpoint       string
-	headers          map[string]string
-	httpClient       *http.Client
-	wsConn           *websocket.Conn
-	connectionReady  bool
-	counter          int64
-	mu               sync.Mutex
-	subs             map[string]subscription
-	wg               sync.WaitGroup
-	authErrorHandler func()
+	httpEndpoint         string
+	wsEndpoint           string
+	subprotocol          string
+	protocol             wsProtocol
+	transport            WebSocketTransport
+	headers              map[string]string
+	httpClient           *http.Client
+	handles              []*wsConnHandle
+	subIndex             map[string]*wsConnHandle
+	connCtx              context.Context
+	connectionReady      bool
+	counter              int64
+	mu                   sync.Mutex
+	wg                   sync.WaitGroup
+	authErrorHandler     func(code int, payload map[string]interface{})
+	lastErrorPayload     map[string]interface{}
+	initPayloadFunc      func(ctx context.Context) (map[string]interface{}, error)
+	backoff              BackoffPolicy
+	maxReconnectDuration time.Duration
+	reconnectListener    func(ReconnectEvent)
+	onGiveUp             func(err error)
+	connectionPool       int
+	asyncEngine          bool
+	pool                 *connectionPool
+	maxSubsPerConn       int
+	idleCloseAfter       time.Duration
+	ackTimeout           time.Duration
+	pingInterval         time.Duration
+	status               ConnectionStatus
+	statusChan           chan ConnectionStatus
 }
 
 type ClientOption func(*GraphQLClient)
@@ -44,32 +64,145 @@ func NewClient(httpEndpoint string, opts ...ClientOption) *GraphQLClient {
 		httpEndpoint: httpEndpoint,
 		httpClient:   &http.Client{},
 		headers:      make(map[string]string),
-		subs:         make(map[string]subscription),
+		subIndex:     make(map[string]*wsConnHandle),
+		subprotocol:  SubprotocolGraphQLTransportWS,
+		transport:    gorillaTransport{},
+		backoff:      defaultBackoffPolicy,
+		ackTimeout:   defaultAckTimeout,
+		statusChan:   make(chan ConnectionStatus, 1),
 	}
 	for _, opt := range opts {
 		opt(client)
 	}
+	client.protocol = protocolFor(client.subprotocol)
+	if client.connectionPool > 0 || client.asyncEngine {
+		client.pool = newConnectionPool(client.connectionPool, client.asyncEngine)
+	}
 	return client
 }
 
+// WithConnectionPool caps the number of upstream WebSocket connections the
+// client will open to demultiplex subscriptions across. Subscribe reuses
+// the first existing connection that isn't full (see
+// WithMaxSubscriptionsPerConn) and opens a new one, up to this cap, only
+// when every existing connection is full; once the cap is reached, new
+// subscriptions are packed onto whichever existing connection currently
+// holds the fewest. 0 (the default) leaves the cap unbounded.
+func WithConnectionPool(max int) ClientOption {
+	return func(client *GraphQLClient) {
+		client.connectionPool = max
+	}
+}
+
+// WithAsyncEngine switches each pooled WebSocket connection from a
+// goroutine-per-connection read loop to a shared epoll/kqueue event loop
+// that only spawns decode work when a connection actually has data ready.
+// Intended for workloads with thousands of concurrent subscriptions spread
+// across a WithConnectionPool. Falls back to the goroutine-per-connection
+// model on platforms or transports that can't expose a file descriptor for
+// polling.
+func WithAsyncEngine(enabled bool) ClientOption {
+	return func(client *GraphQLClient) {
+		client.asyncEngine = enabled
+	}
+}
+
+// WithMaxSubscriptionsPerConn caps how many subscriptions the client will
+// multiplex onto a single upstream WebSocket connection before it's
+// considered full. Once every pooled connection is full, Subscribe opens
+// (and pools, see WithConnectionPool) another one rather than continuing
+// to pile subscriptions onto existing connections. 0 (the default) means a
+// connection is never full on subscription count alone.
+func WithMaxSubscriptionsPerConn(max int) ClientOption {
+	return func(client *GraphQLClient) {
+		client.maxSubsPerConn = max
+	}
+}
+
+// WithIdleCloseAfter sets a grace period a pooled WebSocket connection is
+// kept open after its last subscription completes or is cancelled, in case
+// a new subscription arrives shortly after and can reuse it instead of
+// paying for another dial and handshake. 0 (the default) closes a
+// connection as soon as its last subscription is gone.
+func WithIdleCloseAfter(d time.Duration) ClientOption {
+	return func(client *GraphQLClient) {
+		client.idleCloseAfter = d
+	}
+}
+
+// WithAckTimeout caps how long a WebSocket connection attempt waits for the
+// server's connection_ack before it's treated as a failed dial. Defaults to
+// 10s.
+func WithAckTimeout(d time.Duration) ClientOption {
+	return func(client *GraphQLClient) {
+		client.ackTimeout = d
+	}
+}
+
+// WithPingInterval overrides how often the client proactively pings an
+// idle WebSocket connection to detect a dead peer before the next rolling
+// read deadline would. Defaults to pingPeriod (90% of pongWait).
+func WithPingInterval(d time.Duration) ClientOption {
+	return func(client *GraphQLClient) {
+		client.pingInterval = d
+	}
+}
+
 func WithWebSocket(wsEndpoint string) ClientOption {
 	return func(client *GraphQLClient) {
 		client.wsEndpoint = wsEndpoint
 	}
 }
 
+// WithSubprotocol selects the GraphQL-over-WebSocket subprotocol the client
+// advertises during the handshake and speaks over the wire. Defaults to
+// SubprotocolGraphQLTransportWS; pass SubprotocolGraphQLWS to talk to
+// servers that only support the legacy subscriptions-transport-ws protocol.
+func WithSubprotocol(subprotocol string) ClientOption {
+	return func(client *GraphQLClient) {
+		client.subprotocol = subprotocol
+	}
+}
+
+// WithWebSocketTransport overrides the WebSocketTransport used to dial and
+// communicate with wsEndpoint. Defaults to a github.com/gorilla/websocket
+// based implementation; pass a custom transport (e.g. backed by
+// nhooyr.io/websocket) to control TLS config, proxies, or compression.
+func WithWebSocketTransport(transport WebSocketTransport) ClientOption {
+	return func(client *GraphQLClient) {
+		client.transport = transport
+	}
+}
+
 func (client *GraphQLClient) SetHeader(key, value string) {
 	client.mu.Lock()
 	defer client.mu.Unlock()
 	client.headers[key] = value
 }
 
-func (client *GraphQLClient) SetAuthErrorHandler(handler func()) {
+// SetAuthErrorHandler registers a callback invoked when the WebSocket
+// connection is closed with an authentication-related close code (4401
+// expired/invalid token, 4403 forbidden). payload carries the body of the
+// most recent connection_error/error message received before the close, if
+// any, so the handler can distinguish why auth failed.
+func (client *GraphQLClient) SetAuthErrorHandler(handler func(code int, payload map[string]interface{})) {
 	client.mu.Lock()
 	defer client.mu.Unlock()
 	client.authErrorHandler = handler
 }
 
+// WithConnectionInitPayload builds the payload sent in the connection_init
+// message. It's invoked fresh on every connection attempt, including
+// reconnects, so callers can mint a new token or refresh credentials
+// rather than reusing a stale payload. Defaults to
+// {"Authorization": <the "Authorization" header set via SetHeader>} when
+// unset.
+func WithConnectionInitPayload(fn func(ctx context.Context) (map[string]interface{}, error)) ClientOption {
+	return func(client *GraphQLClient) {
+		client.initPayloadFunc = fn
+	}
+}
+
 func Execute[T interface{}](client *GraphQLClient, operation string, variables map[string]interface{}) (*GraphQLResponse[T], error) {
 	var result GraphQLResponse[T]
 	err := client.execute(operation, variables, &result)
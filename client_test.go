@@ -1,9 +1,14 @@
 package graphqltogo
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,10 +22,10 @@ func TestNewClient(t *testing.T) {
 	assert.Equal(t, "http://example.com", client.httpEndpoint)
 }
 
-func TestSetAuthHeader(t *testing.T) {
+func TestSetHeader(t *testing.T) {
 	client := NewClient("http://example.com")
-	client.SetAuthHeader("Bearer token")
-	assert.Equal(t, "Bearer token", client.authHeader)
+	client.SetHeader("Authorization", "Bearer token")
+	assert.Equal(t, "Bearer token", client.headers["Authorization"])
 }
 
 func TestExecute(t *testing.T) {
@@ -67,8 +72,171 @@ func TestWebSocketConnection(t *testing.T) {
 
 	wsEndpoint := "ws" + server.URL[4:]
 	client := NewClient("http://example.com", WithWebSocket(wsEndpoint))
-	client.SetAuthHeader("Bearer token")
-	err := client.openWebSocket()
+	client.SetHeader("Authorization", "Bearer token")
+	err := client.openWebSocket(context.Background())
 	assert.NoError(t, err)
 	time.Sleep(1 * time.Second) // Give some time for the WebSocket connection to establish
 }
+
+// TestLegacyProtocolSubscriptionFraming exercises the legacy
+// subscriptions-transport-ws framing end to end: start/data/complete
+// message types instead of graphql-transport-ws's subscribe/next/complete.
+func TestLegacyProtocolSubscriptionFraming(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, SubprotocolGraphQLWS, r.Header.Get("Sec-WebSocket-Protocol"))
+		conn, err := upgrader.Upgrade(w, r, nil)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg WebSocketMessage
+			assert.NoError(t, json.Unmarshal(message, &msg))
+
+			switch msg.Type {
+			case "connection_init":
+				assert.NoError(t, conn.WriteJSON(WebSocketMessage{Type: "connection_ack"}))
+			case "start":
+				assert.NoError(t, conn.WriteJSON(WebSocketMessage{
+					ID:      msg.ID,
+					Type:    "data",
+					Payload: map[string]interface{}{"data": map[string]interface{}{"message": "hi"}},
+				}))
+			case "stop":
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsEndpoint := "ws" + server.URL[4:]
+	client := NewClient("http://example.com", WithWebSocket(wsEndpoint), WithSubprotocol(SubprotocolGraphQLWS))
+	defer client.Close()
+
+	dataChan, errChan, cancel := Subscribe[map[string]string](context.Background(), client, "subscription { message }", nil)
+	defer cancel()
+
+	select {
+	case data := <-dataChan:
+		assert.Equal(t, "hi", data["message"])
+	case err := <-errChan:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for subscription data")
+	}
+}
+
+// TestReconnectReplaysSubscriptions drops the WebSocket connection right
+// after a subscribe message arrives and verifies the client reconnects and
+// replays it on the new connection, rather than leaving the subscriber
+// hanging.
+func TestReconnectReplaysSubscriptions(t *testing.T) {
+	var connAttempt int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		attempt := atomic.AddInt32(&connAttempt, 1)
+
+		for {
+			var msg WebSocketMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+
+			switch msg.Type {
+			case "connection_init":
+				assert.NoError(t, conn.WriteJSON(WebSocketMessage{Type: "connection_ack"}))
+			case "subscribe":
+				if attempt == 1 {
+					// Simulate a dropped connection right after the
+					// subscribe is received, before any data is sent.
+					return
+				}
+				assert.NoError(t, conn.WriteJSON(WebSocketMessage{
+					ID:      msg.ID,
+					Type:    "next",
+					Payload: map[string]interface{}{"data": map[string]interface{}{"message": "hi"}},
+				}))
+			case "complete":
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsEndpoint := "ws" + server.URL[4:]
+	client := NewClient(
+		"http://example.com",
+		WithWebSocket(wsEndpoint),
+		WithReconnectBackoff(time.Millisecond, time.Millisecond, 2, false),
+	)
+	defer client.Close()
+
+	dataChan, errChan, cancel := Subscribe[map[string]string](context.Background(), client, "subscription { message }", nil)
+	defer cancel()
+
+	select {
+	case data := <-dataChan:
+		assert.Equal(t, "hi", data["message"])
+	case err := <-errChan:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for subscription data to be replayed after reconnect")
+	}
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&connAttempt), int32(2))
+}
+
+func TestExecuteStreamSSE(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "event: next\ndata: {\"data\":{\"message\":\"one\"}}\n\n")
+		fmt.Fprint(w, "event: next\ndata: {\"data\":{\"message\":\"two\"}}\n\n")
+		fmt.Fprint(w, "event: complete\ndata: {}\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	results, err := ExecuteStream[map[string]string](client, "subscription { message }", nil)
+	assert.NoError(t, err)
+
+	var messages []string
+	for result := range results {
+		messages = append(messages, result.Data["message"])
+	}
+	assert.Equal(t, []string{"one", "two"}, messages)
+}
+
+func TestExecuteStreamMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mw := multipart.NewWriter(w)
+		mw.SetBoundary("graphql")
+		w.Header().Set("Content-Type", "multipart/mixed; boundary=graphql")
+		w.WriteHeader(http.StatusOK)
+
+		part, _ := mw.CreatePart(nil)
+		fmt.Fprint(part, `{"data":{"message":"partial"},"hasNext":true}`)
+		part, _ = mw.CreatePart(nil)
+		fmt.Fprint(part, `{"data":{"message":"final"},"hasNext":false}`)
+		mw.Close()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	results, err := ExecuteStream[map[string]string](client, "query { message }", nil)
+	assert.NoError(t, err)
+
+	var messages []string
+	for result := range results {
+		messages = append(messages, result.Data["message"])
+	}
+	assert.Equal(t, []string{"partial", "final"}, messages)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -67,7 +68,7 @@ func analyticsSummary(client *graphqltogo.GraphQLClient) error {
 	return nil
 }
 
-func newActivity(client *graphqltogo.GraphQLClient, wg *sync.WaitGroup) error {
+func newActivity(ctx context.Context, client *graphqltogo.GraphQLClient, wg *sync.WaitGroup) error {
 	const newActivity = `
 		subscription newActivity {
 			newActivity {
@@ -85,16 +86,24 @@ func newActivity(client *graphqltogo.GraphQLClient, wg *sync.WaitGroup) error {
 		}
 	}
 
-	subChan, _, err := graphqltogo.Subscribe[newActivityResponse](client, newActivity, nil)
-	if err != nil {
-		return fmt.Errorf("error from subscription: %w", err)
-	}
+	dataChan, errChan, _ := graphqltogo.Subscribe[newActivityResponse](ctx, client, newActivity, nil)
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		for msg := range subChan {
-			fmt.Printf(" -- Title: %s\n", msg.Data.NewActivity.Activity.Title)
+		for {
+			select {
+			case data, ok := <-dataChan:
+				if !ok {
+					return
+				}
+				fmt.Printf(" -- Title: %s\n", data.NewActivity.Activity.Title)
+			case err, ok := <-errChan:
+				if !ok {
+					continue
+				}
+				fmt.Println("New activity subscription error:", err)
+			}
 		}
 	}()
 
@@ -106,8 +115,8 @@ func main() {
 	client := graphqltogo.NewClient("http://"+host+"/graphql", graphqltogo.WithWebSocket("ws://"+host+"/graphql"))
 	defer client.Close()
 
-	client.SetAuthErrorHandler(func() {
-		fmt.Println("Handling WebSocket authentication error, re-authenticating...")
+	client.SetAuthErrorHandler(func(code int, payload map[string]interface{}) {
+		fmt.Printf("Handling WebSocket authentication error (code %d, payload %v), re-authenticating...\n", code, payload)
 		if err := authenticate(client, "admin", "admin"); err != nil {
 			fmt.Println("Re-authentication Error:", err)
 		}
@@ -124,7 +133,7 @@ func main() {
 	}
 
 	var wg sync.WaitGroup
-	if err := newActivity(client, &wg); err != nil {
+	if err := newActivity(context.Background(), client, &wg); err != nil {
 		fmt.Println("New Activity Subscription Error:", err)
 		return
 	}
@@ -0,0 +1,51 @@
+package graphqltogo
+
+import (
+	"sync"
+	"time"
+)
+
+// wsConnHandle tracks one upstream WebSocket connection and the
+// subscriptions currently multiplexed onto it. WithConnectionPool,
+// WithAsyncEngine and WithMaxSubscriptionsPerConn all operate in terms of
+// handles: once a handle reaches capacity a new one is opened, and the
+// async engine (see pool.go) registers each handle's connection with the
+// poller independently so reads are demultiplexed per connection rather
+// than per client.
+type wsConnHandle struct {
+	conn      WebSocketConn
+	mu        sync.Mutex
+	subs      map[string]subscription
+	ackChan   chan struct{}
+	idleTimer *time.Timer
+	// ready is set once connection_ack has been received. A read error
+	// before that point means the handshake itself failed (e.g. the ack
+	// timeout closing conn) rather than an established connection dropping,
+	// so handleReadError treats it as a dial failure rather than a dropped
+	// connection to reconnect.
+	ready bool
+	// closing is set by closeHandle before it tears down conn, so the read
+	// error that produces in listen() is recognized as the deliberate result
+	// of an idle-close or client Close() rather than a dropped connection —
+	// otherwise handleReadError would reconnect a handle that was never
+	// meant to come back.
+	closing bool
+}
+
+func newWsConnHandle(conn WebSocketConn) *wsConnHandle {
+	return &wsConnHandle{
+		conn: conn,
+		subs: make(map[string]subscription),
+	}
+}
+
+// full reports whether the handle has reached maxSubsPerConn (0 means
+// unlimited, so it's never full).
+func (h *wsConnHandle) full(maxSubsPerConn int) bool {
+	if maxSubsPerConn <= 0 {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs) >= maxSubsPerConn
+}
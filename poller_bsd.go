@@ -0,0 +1,67 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package graphqltogo
+
+import (
+	"os"
+	"syscall"
+)
+
+// epollPoller is the async engine's event loop on BSD-family systems
+// (including macOS), backed by kqueue. The name is kept consistent with the
+// Linux implementation since both satisfy the same internal contract.
+type epollPoller struct {
+	kq int
+}
+
+func newPoller() (*epollPoller, error) {
+	kq, err := syscall.Kqueue()
+	if err != nil {
+		return nil, err
+	}
+	return &epollPoller{kq: kq}, nil
+}
+
+func (p *epollPoller) add(file *os.File) error {
+	fd := int(file.Fd())
+	event := syscall.Kevent_t{
+		Ident:  uint64(fd),
+		Filter: syscall.EVFILT_READ,
+		Flags:  syscall.EV_ADD | syscall.EV_ENABLE,
+	}
+	_, err := syscall.Kevent(p.kq, []syscall.Kevent_t{event}, nil, nil)
+	return err
+}
+
+func (p *epollPoller) remove(file *os.File) error {
+	fd := int(file.Fd())
+	event := syscall.Kevent_t{
+		Ident:  uint64(fd),
+		Filter: syscall.EVFILT_READ,
+		Flags:  syscall.EV_DELETE,
+	}
+	_, err := syscall.Kevent(p.kq, []syscall.Kevent_t{event}, nil, nil)
+	return err
+}
+
+func (p *epollPoller) wait(timeoutMS int) ([]int32, error) {
+	events := make([]syscall.Kevent_t, 128)
+	var timeout *syscall.Timespec
+	if timeoutMS >= 0 {
+		ts := syscall.NsecToTimespec(int64(timeoutMS) * 1e6)
+		timeout = &ts
+	}
+	n, err := syscall.Kevent(p.kq, nil, events, timeout)
+	if err != nil {
+		return nil, err
+	}
+	ready := make([]int32, 0, n)
+	for i := 0; i < n; i++ {
+		ready = append(ready, int32(events[i].Ident))
+	}
+	return ready, nil
+}
+
+func (p *epollPoller) close() error {
+	return syscall.Close(p.kq)
+}
@@ -0,0 +1,54 @@
+//go:build linux
+
+package graphqltogo
+
+import (
+	"os"
+	"syscall"
+)
+
+// epollPoller is the async engine's event loop on Linux, backed directly by
+// epoll so a client can watch thousands of hijacked WebSocket connections
+// without a goroutine each.
+type epollPoller struct {
+	fd int
+}
+
+func newPoller() (*epollPoller, error) {
+	fd, err := syscall.EpollCreate1(0)
+	if err != nil {
+		return nil, err
+	}
+	return &epollPoller{fd: fd}, nil
+}
+
+func (p *epollPoller) add(file *os.File) error {
+	fd := int(file.Fd())
+	return syscall.EpollCtl(p.fd, syscall.EPOLL_CTL_ADD, fd, &syscall.EpollEvent{
+		Events: syscall.EPOLLIN,
+		Fd:     int32(fd),
+	})
+}
+
+func (p *epollPoller) remove(file *os.File) error {
+	return syscall.EpollCtl(p.fd, syscall.EPOLL_CTL_DEL, int(file.Fd()), nil)
+}
+
+// wait blocks until one or more registered descriptors have data ready, or
+// timeoutMS elapses (-1 blocks indefinitely), returning their fds.
+func (p *epollPoller) wait(timeoutMS int) ([]int32, error) {
+	events := make([]syscall.EpollEvent, 128)
+	n, err := syscall.EpollWait(p.fd, events, timeoutMS)
+	if err != nil {
+		return nil, err
+	}
+	ready := make([]int32, 0, n)
+	for i := 0; i < n; i++ {
+		ready = append(ready, events[i].Fd)
+	}
+	return ready, nil
+}
+
+func (p *epollPoller) close() error {
+	return syscall.Close(p.fd)
+}
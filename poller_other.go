@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package graphqltogo
+
+import "os"
+
+// epollPoller has no implementation on this platform; newPoller reports
+// that so the connection pool falls back to the goroutine-per-connection
+// model instead.
+type epollPoller struct{}
+
+func newPoller() (*epollPoller, error) {
+	return nil, errUnsupportedPoller
+}
+
+func (p *epollPoller) add(file *os.File) error              { return errUnsupportedPoller }
+func (p *epollPoller) remove(file *os.File) error           { return errUnsupportedPoller }
+func (p *epollPoller) wait(timeoutMS int) ([]int32, error)  { return nil, errUnsupportedPoller }
+func (p *epollPoller) close() error                         { return nil }
@@ -0,0 +1,153 @@
+package graphqltogo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var errUnsupportedPoller = errors.New("graphqltogo: epoll/kqueue async engine is not supported on this platform")
+
+// fileConn is implemented by WebSocketConn implementations that can expose
+// their underlying OS file descriptor. It's how the async engine registers
+// a connection with the poller; transports that can't support it (most
+// custom WebSocketTransport implementations) just fall back to the
+// goroutine-per-connection model.
+type fileConn interface {
+	File() (*os.File, error)
+}
+
+// wsConnState is what the async engine tracks per pooled connection: the
+// connection and handle it belongs to, and the client whose message
+// handling it feeds into.
+type wsConnState struct {
+	conn   WebSocketConn
+	file   *os.File
+	client *GraphQLClient
+	handle *wsConnHandle
+}
+
+// connectionPool demultiplexes many subscriptions across a bounded number
+// of upstream WebSocket connections. With WithAsyncEngine enabled, it backs
+// connections with a central epoll event loop (see poller_linux.go) instead
+// of a goroutine per connection, and only spawns decode work on a bounded
+// worker pool when a connection actually has data ready. See
+// WithConnectionPool and WithAsyncEngine.
+type connectionPool struct {
+	mu          sync.Mutex
+	maxConns    int
+	conns       map[int]*wsConnState // fd -> connection state
+	poller      *epollPoller
+	workQueue   chan func()
+	asyncEngine bool
+}
+
+// newConnectionPool builds a pool sized to maxConns (0 means unbounded). If
+// asyncEngine is requested and the platform supports epoll/kqueue, a poller
+// and a bounded worker pool are started; otherwise it silently falls back
+// to the existing goroutine-per-connection model.
+func newConnectionPool(maxConns int, asyncEngine bool) *connectionPool {
+	pool := &connectionPool{
+		maxConns: maxConns,
+		conns:    make(map[int]*wsConnState),
+	}
+
+	if !asyncEngine {
+		return pool
+	}
+
+	poller, err := newPoller()
+	if err != nil {
+		fmt.Println("Async engine unavailable, falling back to goroutine-per-connection:", err)
+		return pool
+	}
+
+	pool.poller = poller
+	pool.asyncEngine = true
+	pool.workQueue = make(chan func(), 256)
+	const workers = 8
+	for i := 0; i < workers; i++ {
+		go pool.worker()
+	}
+	go pool.loop()
+	return pool
+}
+
+func (pool *connectionPool) worker() {
+	for job := range pool.workQueue {
+		job()
+	}
+}
+
+// loop blocks in the poller's wait until one or more registered connections
+// have data ready, then hands each off to the worker pool so a slow JSON
+// decode on one subscription can't starve the others.
+func (pool *connectionPool) loop() {
+	for {
+		ready, err := pool.poller.wait(-1)
+		if err != nil {
+			return
+		}
+		for _, fd := range ready {
+			pool.mu.Lock()
+			state, ok := pool.conns[int(fd)]
+			pool.mu.Unlock()
+			if !ok {
+				continue
+			}
+			pool.workQueue <- func() { pool.drain(state) }
+		}
+	}
+}
+
+// drain reads a single ready message off state's connection and dispatches
+// it through the owning client's normal message handling. Epoll is
+// level-triggered here, so any remaining buffered data simply wakes the
+// loop again on the next wait.
+func (pool *connectionPool) drain(state *wsConnState) {
+	var result WebSocketMessage
+	if err := state.conn.ReadJSON(&result); err != nil {
+		state.client.handleReadError(state.handle, err)
+		pool.unregister(state)
+		return
+	}
+	state.conn.SetReadDeadline(time.Now().Add(pongWait))
+	state.client.handleMessage(state.handle, result)
+}
+
+// register adds conn to the async engine, falling back to the caller's
+// existing goroutine-per-connection model (via a non-nil error) if the
+// connection doesn't expose a file descriptor or the pool has no poller.
+func (pool *connectionPool) register(client *GraphQLClient, conn WebSocketConn, handle *wsConnHandle) error {
+	if pool == nil || !pool.asyncEngine {
+		return errUnsupportedPoller
+	}
+
+	fc, ok := conn.(fileConn)
+	if !ok {
+		return errUnsupportedPoller
+	}
+	file, err := fc.File()
+	if err != nil {
+		return err
+	}
+
+	if err := pool.poller.add(file); err != nil {
+		return err
+	}
+
+	pool.mu.Lock()
+	pool.conns[int(file.Fd())] = &wsConnState{conn: conn, file: file, client: client, handle: handle}
+	pool.mu.Unlock()
+	return nil
+}
+
+func (pool *connectionPool) unregister(state *wsConnState) {
+	pool.mu.Lock()
+	delete(pool.conns, int(state.file.Fd()))
+	pool.mu.Unlock()
+	pool.poller.remove(state.file)
+	state.file.Close()
+}
@@ -0,0 +1,111 @@
+package graphqltogo
+
+// Subprotocol identifies which GraphQL-over-WebSocket wire protocol a
+// client speaks. See WithSubprotocol.
+const (
+	// SubprotocolGraphQLTransportWS is the current graphql-ws reference
+	// protocol (subscribe/next/error/complete, ping/pong).
+	SubprotocolGraphQLTransportWS = "graphql-transport-ws"
+	// SubprotocolGraphQLWS is the legacy Apollo subscriptions-transport-ws
+	// protocol (start/stop/data/error/complete, connection_keep_alive)
+	// still spoken by gqlgen, Apollo Server v2, and Hasura in legacy mode.
+	SubprotocolGraphQLWS = "graphql-ws"
+)
+
+// wsProtocol abstracts the message types used by a GraphQL-over-WebSocket
+// subprotocol so the rest of the client can stay protocol-agnostic.
+type wsProtocol interface {
+	name() string
+	subscribeMessage(subID, operation string, variables map[string]interface{}) WebSocketMessage
+	completeMessage(subID string) WebSocketMessage
+	isData(msgType string) bool
+	isError(msgType string) bool
+	isComplete(msgType string) bool
+	isKeepAlive(msgType string) bool
+	// keepAliveReply returns the message the client should send in
+	// response to a keep-alive, or nil if the protocol expects none.
+	keepAliveReply() *WebSocketMessage
+	// pingMessage returns the message a client proactively sends on a
+	// ticker to prove liveness, or nil if the protocol has no client-
+	// initiated ping.
+	pingMessage() *WebSocketMessage
+}
+
+type graphqlTransportWSProtocol struct{}
+
+func (graphqlTransportWSProtocol) name() string { return SubprotocolGraphQLTransportWS }
+
+func (graphqlTransportWSProtocol) subscribeMessage(subID, operation string, variables map[string]interface{}) WebSocketMessage {
+	return WebSocketMessage{
+		ID:   subID,
+		Type: "subscribe",
+		Payload: map[string]interface{}{
+			"query":     operation,
+			"variables": variables,
+		},
+	}
+}
+
+func (graphqlTransportWSProtocol) completeMessage(subID string) WebSocketMessage {
+	return WebSocketMessage{ID: subID, Type: "complete"}
+}
+
+func (graphqlTransportWSProtocol) isData(msgType string) bool     { return msgType == "next" }
+func (graphqlTransportWSProtocol) isError(msgType string) bool    { return msgType == "error" }
+func (graphqlTransportWSProtocol) isComplete(msgType string) bool { return msgType == "complete" }
+func (graphqlTransportWSProtocol) isKeepAlive(msgType string) bool { return msgType == "ping" }
+func (graphqlTransportWSProtocol) keepAliveReply() *WebSocketMessage {
+	return &WebSocketMessage{Type: "pong"}
+}
+
+func (graphqlTransportWSProtocol) pingMessage() *WebSocketMessage {
+	return &WebSocketMessage{Type: "ping"}
+}
+
+// graphqlWSProtocol implements the legacy subscriptions-transport-ws wire
+// format.
+type graphqlWSProtocol struct{}
+
+func (graphqlWSProtocol) name() string { return SubprotocolGraphQLWS }
+
+func (graphqlWSProtocol) subscribeMessage(subID, operation string, variables map[string]interface{}) WebSocketMessage {
+	return WebSocketMessage{
+		ID:   subID,
+		Type: "start",
+		Payload: map[string]interface{}{
+			"query":     operation,
+			"variables": variables,
+		},
+	}
+}
+
+func (graphqlWSProtocol) completeMessage(subID string) WebSocketMessage {
+	return WebSocketMessage{ID: subID, Type: "stop"}
+}
+
+func (graphqlWSProtocol) isData(msgType string) bool  { return msgType == "data" }
+func (graphqlWSProtocol) isError(msgType string) bool { return msgType == "error" }
+func (graphqlWSProtocol) isComplete(msgType string) bool {
+	return msgType == "complete"
+}
+func (graphqlWSProtocol) isKeepAlive(msgType string) bool {
+	return msgType == "ka" || msgType == "connection_keep_alive"
+}
+
+// keepAliveReply is nil: subscriptions-transport-ws keep-alives are a
+// one-way heartbeat from the server, the client just needs to keep reading.
+func (graphqlWSProtocol) keepAliveReply() *WebSocketMessage { return nil }
+
+func (graphqlWSProtocol) pingMessage() *WebSocketMessage {
+	return &WebSocketMessage{Type: "connection_keep_alive"}
+}
+
+// protocolFor resolves the wsProtocol implementation for a subprotocol
+// string, defaulting to the current graphql-transport-ws protocol for any
+// unrecognized value.
+func protocolFor(subprotocol string) wsProtocol {
+	if subprotocol == SubprotocolGraphQLWS {
+		return graphqlWSProtocol{}
+	}
+	return graphqlTransportWSProtocol{}
+}
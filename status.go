@@ -0,0 +1,54 @@
+package graphqltogo
+
+// ConnectionStatus describes the observable state of a client's WebSocket
+// connection(s). See (*GraphQLClient).Status and StatusChan.
+type ConnectionStatus int
+
+const (
+	StatusDisconnected ConnectionStatus = iota
+	StatusConnecting
+	StatusConnected
+	StatusReconnecting
+)
+
+func (s ConnectionStatus) String() string {
+	switch s {
+	case StatusConnecting:
+		return "Connecting"
+	case StatusConnected:
+		return "Connected"
+	case StatusReconnecting:
+		return "Reconnecting"
+	default:
+		return "Disconnected"
+	}
+}
+
+// Status returns the client's most recently observed ConnectionStatus.
+func (client *GraphQLClient) Status() ConnectionStatus {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.status
+}
+
+// StatusChan returns a channel that receives a value every time the
+// client's ConnectionStatus changes (dial in progress, connected,
+// reconnecting after a dropped connection, or disconnected). It's buffered
+// with capacity 1 and a send is dropped rather than blocking the connection
+// goroutine if the previous value hasn't been consumed yet, so a value read
+// from it late can be older than the current status; Status always returns
+// the latest one.
+func (client *GraphQLClient) StatusChan() <-chan ConnectionStatus {
+	return client.statusChan
+}
+
+func (client *GraphQLClient) setStatus(status ConnectionStatus) {
+	client.mu.Lock()
+	client.status = status
+	client.mu.Unlock()
+
+	select {
+	case client.statusChan <- status:
+	default:
+	}
+}
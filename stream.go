@@ -0,0 +1,168 @@
+package graphqltogo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// ExecuteStream executes operation like Execute, but negotiates a streaming
+// transport so servers can send multiple payloads for a single query, e.g.
+// for @defer/@stream directives. It supports GraphQL-over-SSE
+// (text/event-stream) and the incremental-delivery multipart/mixed format,
+// and falls back to decoding a single plain JSON response against servers
+// that don't support either, so the same call works everywhere. The
+// returned channel is closed once the server reports no further payloads
+// are coming.
+func ExecuteStream[T interface{}](client *GraphQLClient, operation string, variables map[string]interface{}) (<-chan *GraphQLResponse[T], error) {
+	resp, err := client.executeStreamRequest(operation, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan *GraphQLResponse[T])
+	contentType := resp.Header.Get("Content-Type")
+
+	switch {
+	case strings.HasPrefix(contentType, "text/event-stream"):
+		go decodeSSEStream[T](resp, results)
+	case strings.HasPrefix(contentType, "multipart/mixed"):
+		go decodeMultipartStream[T](resp, results)
+	default:
+		go decodeSingleResponse[T](resp, results)
+	}
+
+	return results, nil
+}
+
+func (client *GraphQLClient) executeStreamRequest(operation string, variables map[string]interface{}) (*http.Response, error) {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"query":     operation,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", client.httpEndpoint, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/graphql-response+json, text/event-stream, multipart/mixed")
+	client.mu.Lock()
+	for key, value := range client.headers {
+		req.Header.Set(key, value)
+	}
+	client.mu.Unlock()
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// decodeSingleResponse handles the plain POST-JSON fallback: one decode,
+// one value on the channel.
+func decodeSingleResponse[T interface{}](resp *http.Response, results chan<- *GraphQLResponse[T]) {
+	defer close(results)
+	defer resp.Body.Close()
+
+	var result GraphQLResponse[T]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return
+	}
+	results <- &result
+}
+
+// decodeSSEStream parses a GraphQL-over-SSE response, forwarding each
+// "next" event's data payload on results until a "complete" event or the
+// stream ends.
+func decodeSSEStream[T interface{}](resp *http.Response, results chan<- *GraphQLResponse[T]) {
+	defer close(results)
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	var event string
+	var data strings.Builder
+
+	// flush reports whether the event just ended was "complete".
+	flush := func() bool {
+		defer data.Reset()
+		if event == "complete" {
+			return true
+		}
+		if data.Len() == 0 {
+			return false
+		}
+		var result GraphQLResponse[T]
+		if err := json.Unmarshal([]byte(data.String()), &result); err != nil {
+			return false
+		}
+		results <- &result
+		return false
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if flush() {
+				return
+			}
+			event = ""
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	flush()
+}
+
+// decodeMultipartStream parses an incremental-delivery (@defer/@stream)
+// multipart/mixed response, forwarding each part on results until a part
+// reports hasNext: false or the stream ends.
+func decodeMultipartStream[T interface{}](resp *http.Response, results chan<- *GraphQLResponse[T]) {
+	defer close(results)
+	defer resp.Body.Close()
+
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return
+	}
+
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		var frame struct {
+			GraphQLResponse[T]
+			HasNext bool `json:"hasNext"`
+		}
+		if err := json.NewDecoder(part).Decode(&frame); err != nil {
+			return
+		}
+		results <- &frame.GraphQLResponse
+		if !frame.HasNext {
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package graphqltogo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GraphQLErrorLocation is a source location attached to a GraphQLError, per
+// the GraphQL spec.
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError is a single error as defined by the GraphQL spec's response
+// format.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// SubscriptionError is delivered on the error channel returned by Subscribe
+// for GraphQL-level errors, tagged with the operation ID they came from.
+type SubscriptionError struct {
+	OperationID string
+	Errors      []GraphQLError
+}
+
+func (e *SubscriptionError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("subscription %s: unknown GraphQL error", e.OperationID)
+	}
+	return fmt.Sprintf("subscription %s: %s", e.OperationID, e.Errors[0].Message)
+}
+
+// decodeGraphQLErrors best-effort decodes raw into a slice of GraphQLError,
+// accepting either a GraphQL-ws style error array or a single error object
+// (used by the legacy subscriptions-transport-ws protocol).
+func decodeGraphQLErrors(raw interface{}) []GraphQLError {
+	if raw == nil {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+
+	var list []GraphQLError
+	if err := json.Unmarshal(data, &list); err == nil && len(list) > 0 {
+		return list
+	}
+
+	var single GraphQLError
+	if err := json.Unmarshal(data, &single); err == nil && single.Message != "" {
+		return []GraphQLError{single}
+	}
+
+	return nil
+}
+
+// Subscribe opens a GraphQL subscription and returns a channel of the raw
+// result type T, a channel carrying both GraphQL-level errors (as
+// *SubscriptionError) and transport errors, and a cancel func that sends
+// `complete` upstream and tears down the subscription's goroutine.
+//
+// ctx binds the subscription's lifetime to the caller's scope: cancelling
+// it has the same effect as calling the returned cancel func. It's also
+// used to bound the initial connect/dial attempt if a WebSocket connection
+// isn't already open.
+func Subscribe[T any](ctx context.Context, client *GraphQLClient, operation string, variables map[string]interface{}) (<-chan T, <-chan error, func()) {
+	dataChan := make(chan T)
+	errChan := make(chan error, 1)
+
+	rawChan, subErrChan, cancel, err := client.subscribe(ctx, operation, variables, func() interface{} {
+		return new(T)
+	})
+	if err != nil {
+		errChan <- err
+		close(errChan)
+		close(dataChan)
+		return dataChan, errChan, func() {}
+	}
+
+	client.wg.Add(1)
+	go func() {
+		defer client.wg.Done()
+		defer close(dataChan)
+		defer close(errChan)
+		for {
+			select {
+			case <-ctx.Done():
+				cancel()
+				return
+			case msg, ok := <-rawChan:
+				if !ok {
+					return
+				}
+				dataChan <- *msg.(*T)
+			case subErr, ok := <-subErrChan:
+				if !ok {
+					subErrChan = nil // stop selecting on a closed channel
+					continue
+				}
+				errChan <- subErr
+			}
+		}
+	}()
+
+	return dataChan, errChan, cancel
+}
@@ -0,0 +1,92 @@
+package graphqltogo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Standard WebSocket close codes (RFC 6455) used by the client's
+// close-handling logic, kept independent of any particular
+// WebSocketTransport implementation.
+const (
+	closeNormalClosure = 1000
+	closeGoingAway     = 1001
+)
+
+// WebSocketConn is the minimal connection surface the client needs from a
+// WebSocket implementation.
+type WebSocketConn interface {
+	ReadJSON(v interface{}) error
+	WriteJSON(v interface{}) error
+	Close() error
+	// CloseCode reports the close code carried by err, if any, so callers
+	// can distinguish a normal shutdown from an authentication failure or
+	// other server-initiated close without depending on a specific
+	// WebSocket library's error type.
+	CloseCode(err error) (code int, ok bool)
+	// SetReadDeadline and SetWriteDeadline back the client's health
+	// subsystem (see pongWait/writeWait in websocket.go): a rolling read
+	// deadline forces ReadJSON to fail, triggering a reconnect, if no
+	// traffic at all arrives on an otherwise-idle connection.
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// WebSocketTransport abstracts the WebSocket dialer so users can swap the
+// default github.com/gorilla/websocket implementation for another (e.g.
+// nhooyr.io/websocket) or a custom dialer with their own TLS config,
+// proxies, or compression settings. See WithWebSocketTransport.
+type WebSocketTransport interface {
+	Dial(ctx context.Context, url string, header http.Header) (WebSocketConn, error)
+}
+
+// gorillaTransport is the default WebSocketTransport, backed by
+// github.com/gorilla/websocket.
+type gorillaTransport struct{}
+
+func (gorillaTransport) Dial(ctx context.Context, url string, header http.Header) (WebSocketConn, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+	return gorillaConn{conn: conn}, nil
+}
+
+// gorillaConn adapts *websocket.Conn to WebSocketConn.
+type gorillaConn struct {
+	conn *websocket.Conn
+}
+
+func (g gorillaConn) ReadJSON(v interface{}) error  { return g.conn.ReadJSON(v) }
+func (g gorillaConn) WriteJSON(v interface{}) error { return g.conn.WriteJSON(v) }
+func (g gorillaConn) Close() error                  { return g.conn.Close() }
+
+func (g gorillaConn) SetReadDeadline(t time.Time) error  { return g.conn.SetReadDeadline(t) }
+func (g gorillaConn) SetWriteDeadline(t time.Time) error { return g.conn.SetWriteDeadline(t) }
+
+func (g gorillaConn) CloseCode(err error) (int, bool) {
+	closeErr, ok := err.(*websocket.CloseError)
+	if !ok {
+		return 0, false
+	}
+	return closeErr.Code, true
+}
+
+// File exposes the connection's underlying OS file descriptor so the async
+// engine (see WithAsyncEngine) can register it with the platform poller.
+// It satisfies the internal fileConn interface; dup'ing the fd via
+// (*net.TCPConn).File puts the original socket into blocking mode, which
+// only matters when the async engine is enabled.
+func (g gorillaConn) File() (*os.File, error) {
+	tcpConn, ok := g.conn.UnderlyingConn().(*net.TCPConn)
+	if !ok {
+		return nil, fmt.Errorf("underlying connection does not support file descriptor access")
+	}
+	return tcpConn.File()
+}
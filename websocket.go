@@ -1,177 +1,419 @@
 package graphqltogo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 	"sync/atomic"
 	"time"
-
-	"github.com/gorilla/websocket"
 )
 
-const maxRetries = 5
-const retryInterval = 2 * time.Second
-
 type WebSocketMessage struct {
 	Type    string                 `json:"type"`
 	ID      string                 `json:"id,omitempty"`
 	Payload map[string]interface{} `json:"payload,omitempty"`
 }
 
-func (client *GraphQLClient) openWebSocket() error {
+// defaultAckTimeout is how long a connection attempt waits for the
+// server's connection_ack before giving up, unless overridden with
+// WithAckTimeout.
+const defaultAckTimeout = 10 * time.Second
+
+// Timing constants for the WebSocket health subsystem: writeWait bounds how
+// long a ping write may block, pongWait is the rolling read deadline a
+// connection must produce traffic within (server data or a ping reply)
+// before it's considered dead, and pingPeriod is how often the client
+// proactively pings an otherwise-idle connection to find out before
+// pongWait would. See WithPingInterval.
+const (
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
+)
+
+// openWebSocket ensures at least one upstream WebSocket connection is
+// open, dialing one if the client doesn't have any yet. Subscribe uses
+// pickHandle, not this method, to choose which connection a given
+// subscription is multiplexed onto.
+func (client *GraphQLClient) openWebSocket(ctx context.Context) error {
 	client.mu.Lock()
-	if client.wsConn != nil {
-		client.mu.Unlock()
+	hasHandle := len(client.handles) > 0
+	client.mu.Unlock()
+	if hasHandle {
 		return nil
 	}
-	client.mu.Unlock() // Unlock before dialing
+
+	_, err := client.openHandle(ctx)
+	return err
+}
+
+// openHandle dials a new upstream WebSocket connection, completes the
+// connection_init/connection_ack handshake, registers it with the async
+// engine (or starts a goroutine-per-connection read loop), and adds it to
+// the client's pool of connections.
+func (client *GraphQLClient) openHandle(ctx context.Context) (*wsConnHandle, error) {
+	client.mu.Lock()
+	client.connCtx = ctx
+	client.mu.Unlock()
+	client.setStatus(StatusConnecting)
 
 	header := http.Header{}
-	header.Set("Sec-WebSocket-Protocol", "graphql-transport-ws")
+	header.Set("Sec-WebSocket-Protocol", client.subprotocol)
+	client.mu.Lock()
+	for key, value := range client.headers {
+		header.Set(key, value)
+	}
+	client.mu.Unlock()
 
-	conn, err := client.dialWebSocket(header)
+	conn, err := client.dialWebSocket(ctx, header)
 	if err != nil {
-		return err
+		client.setStatus(StatusDisconnected)
+		return nil, err
+	}
+
+	handle := newWsConnHandle(conn)
+	handle.mu.Lock()
+	handle.ackChan = make(chan struct{})
+	ackChan := handle.ackChan
+	handle.mu.Unlock()
+
+	// Start reading before the handshake completes so the connection_ack
+	// that waitForAck blocks on is actually picked up off the wire; a read
+	// failure here is harmless because handle.ready is still false (see
+	// handleReadError).
+	client.startReading(handle, conn)
+
+	if err := client.sendInitMessage(ctx, conn); err != nil {
+		conn.Close()
+		client.setStatus(StatusDisconnected)
+		return nil, err
+	}
+
+	if err := client.waitForAck(ctx, ackChan); err != nil {
+		conn.Close()
+		client.setStatus(StatusDisconnected)
+		return nil, err
 	}
 
+	handle.mu.Lock()
+	handle.ready = true
+	handle.mu.Unlock()
+
 	client.mu.Lock()
-	client.wsConn = conn
+	client.handles = append(client.handles, handle)
 	client.mu.Unlock()
 
-	if err := client.sendInitMessage(); err != nil {
-		return err
-	}
+	go client.healthLoop(ctx, handle)
 
-	go client.listen()
+	client.setStatus(StatusConnected)
+	return handle, nil
+}
 
-	return nil
+// startReading registers conn with the async engine, falling back to a
+// goroutine-per-connection read loop if the engine isn't enabled or the
+// connection can't be registered with it.
+func (client *GraphQLClient) startReading(handle *wsConnHandle, conn WebSocketConn) {
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	if err := client.pool.register(client, conn, handle); err != nil {
+		go client.listen(handle)
+	}
 }
 
-func (client *GraphQLClient) dialWebSocket(header http.Header) (*websocket.Conn, error) {
-	var conn *websocket.Conn
-	var resp *http.Response
-	var err error
+func (client *GraphQLClient) dialWebSocket(ctx context.Context, header http.Header) (WebSocketConn, error) {
+	start := time.Now()
 
-	for i := 0; i < maxRetries; i++ {
+	for attempt := 0; ; attempt++ {
 		fmt.Println("Connecting to WebSocket endpoint:", client.wsEndpoint)
-		conn, resp, err = websocket.DefaultDialer.Dial(client.wsEndpoint, header)
+		conn, err := client.transport.Dial(ctx, client.wsEndpoint, header)
+		client.emitReconnectEvent(attempt, err)
 		if err == nil {
-			break
+			return conn, nil
 		}
 
-		client.logDialError(resp, err)
-		fmt.Printf("Retrying in %v...\n", retryInterval)
-		time.Sleep(retryInterval)
-	}
+		if client.maxReconnectDuration > 0 && time.Since(start) > client.maxReconnectDuration {
+			client.emitGiveUp(err)
+			return nil, fmt.Errorf("failed to dial WebSocket within %v: %w", client.maxReconnectDuration, err)
+		}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial WebSocket after %d attempts: %w", maxRetries, err)
+		delay := client.backoff.duration(attempt)
+		fmt.Printf("Dial error: %v. Retrying in %v...\n", err, delay)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
 	}
+}
 
-	return conn, nil
+// emitReconnectEvent notifies a WithReconnectListener of a dial/reconnect
+// attempt and its outcome.
+func (client *GraphQLClient) emitReconnectEvent(attempt int, err error) {
+	if client.reconnectListener != nil {
+		client.reconnectListener(ReconnectEvent{Attempt: attempt, Err: err})
+	}
 }
 
-func (client *GraphQLClient) logDialError(resp *http.Response, err error) {
-	if resp != nil {
-		fmt.Println("Handshake failed with status:", resp.Status)
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Println("Response body:", string(body))
-	} else {
-		fmt.Println("Dial error:", err)
+// emitGiveUp notifies a WithOnGiveUp callback that the reconnect budget set
+// by WithMaxReconnectDuration has been exhausted.
+func (client *GraphQLClient) emitGiveUp(lastErr error) {
+	if client.onGiveUp != nil {
+		client.onGiveUp(lastErr)
 	}
 }
 
-func (client *GraphQLClient) sendInitMessage() error {
+func (client *GraphQLClient) sendInitMessage(ctx context.Context, conn WebSocketConn) error {
+	payload, err := client.buildInitPayload(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build connection_init payload: %w", err)
+	}
+
 	initMessage := map[string]interface{}{
-		"type": "connection_init",
-		"payload": map[string]interface{}{
-			"Authorization": client.authHeader,
-		},
+		"type":    "connection_init",
+		"payload": payload,
 	}
-	if err := client.wsConn.WriteJSON(initMessage); err != nil {
+	if err := conn.WriteJSON(initMessage); err != nil {
 		return fmt.Errorf("failed to send init message: %w", err)
 	}
 	return nil
 }
 
-func (client *GraphQLClient) listen() {
+// waitForAck blocks until the connection_ack arrives on ackChan (closed by
+// handleMessage once it reads one), the ack timeout elapses, or ctx is
+// cancelled. Without this, a caller could start a subscription before the
+// server has acknowledged connection_init, which some servers reject.
+func (client *GraphQLClient) waitForAck(ctx context.Context, ackChan chan struct{}) error {
+	timeout := client.ackTimeout
+	if timeout <= 0 {
+		timeout = defaultAckTimeout
+	}
+
+	select {
+	case <-ackChan:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out waiting for connection_ack after %v", timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// buildInitPayload resolves the connection_init payload, preferring a
+// WithConnectionInitPayload callback when one is set and falling back to
+// the "Authorization" header set via SetHeader otherwise.
+func (client *GraphQLClient) buildInitPayload(ctx context.Context) (map[string]interface{}, error) {
+	if client.initPayloadFunc != nil {
+		return client.initPayloadFunc(ctx)
+	}
+
+	client.mu.Lock()
+	auth := client.headers["Authorization"]
+	client.mu.Unlock()
+	if auth == "" {
+		return nil, nil
+	}
+	return map[string]interface{}{"Authorization": auth}, nil
+}
+
+// listen is the goroutine-per-connection read loop used when handle's
+// connection isn't registered with the async engine (see pool.go).
+func (client *GraphQLClient) listen(handle *wsConnHandle) {
 	client.wg.Add(1)
 	defer client.wg.Done()
 
 	for {
-		client.mu.Lock()
-		conn := client.wsConn
-		client.mu.Unlock()
-
-		if conn == nil {
-			fmt.Println("WebSocket connection is nil, stopping listen goroutine")
+		var result WebSocketMessage
+		if err := handle.conn.ReadJSON(&result); err != nil {
+			client.handleReadError(handle, err)
 			return
 		}
+		handle.conn.SetReadDeadline(time.Now().Add(pongWait))
+		client.handleMessage(handle, result)
+	}
+}
 
-		var result WebSocketMessage
-		if err := conn.ReadJSON(&result); err != nil {
-			client.handleReadError(err)
+// healthLoop proactively pings an otherwise-idle connection on a
+// WithPingInterval ticker so a dead peer is detected without waiting for
+// the rolling pongWait read deadline set in listen/pool.drain to expire on
+// its own. It exits once handle is no longer one of the client's live
+// connections (closed, or reconnected onto a replacement handle).
+func (client *GraphQLClient) healthLoop(ctx context.Context, handle *wsConnHandle) {
+	interval := client.pingInterval
+	if interval <= 0 {
+		interval = pingPeriod
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			if !client.handleActive(handle) {
+				return
+			}
+			ping := client.protocol.pingMessage()
+			if ping == nil {
+				continue
+			}
+			handle.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := handle.conn.WriteJSON(*ping); err != nil {
+				return
+			}
 		}
-
-		client.handleMessage(result)
 	}
 }
 
-func (client *GraphQLClient) handleReadError(err error) {
+// handleActive reports whether handle is still one of the client's live
+// connections, so healthLoop can stop pinging a connection that's already
+// been closed or replaced by a reconnect.
+func (client *GraphQLClient) handleActive(handle *wsConnHandle) bool {
 	client.mu.Lock()
-	client.wsConn = nil
-	client.mu.Unlock()
+	defer client.mu.Unlock()
+	for _, h := range client.handles {
+		if h == handle {
+			return true
+		}
+	}
+	return false
+}
+
+func (client *GraphQLClient) handleReadError(handle *wsConnHandle, err error) {
+	handle.mu.Lock()
+	ready := handle.ready
+	closing := handle.closing
+	handle.mu.Unlock()
+
+	client.removeHandle(handle)
+
+	if !ready {
+		// The handshake itself failed (e.g. openHandle closed conn after an
+		// ack timeout); openHandle's own error path already handles
+		// reporting this, so there's nothing to reconnect.
+		return
+	}
+
+	if closing {
+		// closeHandle already tore this connection down on purpose (idle
+		// timeout or client Close()); the read error it produces here isn't
+		// a dropped connection and reconnecting would just open an
+		// unwanted replacement.
+		return
+	}
+
+	code, hasCode := handle.conn.CloseCode(err)
 
-	if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+	if hasCode && (code == closeNormalClosure || code == closeGoingAway) {
 		fmt.Println("WebSocket closed:", err)
 		return
 	}
 
-	if websocket.IsCloseError(err, 4401, 4403) {
+	if hasCode && (code == 4401 || code == 4403) {
 		fmt.Println("Authentication error:", err)
+		client.mu.Lock()
+		payload := client.lastErrorPayload
+		client.lastErrorPayload = nil
+		client.mu.Unlock()
 		if client.authErrorHandler != nil {
-			client.authErrorHandler()
+			client.authErrorHandler(code, payload)
 		}
 	} else {
 		fmt.Println("WebSocket read error:", err)
 	}
-	client.reconnect()
+	client.reconnectHandle(handle)
 }
 
-func (client *GraphQLClient) handleMessage(result WebSocketMessage) {
-	switch result.Type {
-	case "next", "error":
-		client.handleDataMessage(result)
-	case "complete":
-		client.handleCompleteMessage(result.ID)
-	case "connection_ack":
+func (client *GraphQLClient) handleMessage(handle *wsConnHandle, result WebSocketMessage) {
+	protocol := client.protocol
+	switch {
+	case result.Type == "connection_error":
+		client.handleConnectionError(result.Payload)
+	case protocol.isData(result.Type), protocol.isError(result.Type):
+		if protocol.isError(result.Type) {
+			client.mu.Lock()
+			client.lastErrorPayload = result.Payload
+			client.mu.Unlock()
+		}
+		client.handleDataMessage(handle, result)
+	case protocol.isComplete(result.Type):
+		client.handleCompleteMessage(handle, result.ID)
+	case protocol.isKeepAlive(result.Type):
+		client.handleKeepAlive(handle)
+	case result.Type == "connection_ack":
 		fmt.Println("WebSocket connection established")
-	case "ping":
-		client.sendPong()
-	case "pong":
+		client.handleAck(handle)
+	case result.Type == "pong":
 		// No action needed
 	default:
 		fmt.Println("Unknown message type:", result.Type)
 	}
 }
 
-func (client *GraphQLClient) handleDataMessage(result WebSocketMessage) {
-	subID := result.ID
-	payload := result.Payload
+// handleAck unblocks openHandle's waitForAck once the server's
+// connection_ack arrives. Safe to call more than once; only the first call
+// (per handle) finds a non-nil ackChan to close.
+func (client *GraphQLClient) handleAck(handle *wsConnHandle) {
+	handle.mu.Lock()
+	ackChan := handle.ackChan
+	handle.ackChan = nil
+	handle.mu.Unlock()
+
+	if ackChan != nil {
+		close(ackChan)
+	}
+}
+
+// handleKeepAlive replies to a server keep-alive if the active subprotocol
+// expects a response (graphql-transport-ws ping/pong); subscriptions-transport-ws
+// keep-alives are a one-way heartbeat and need no reply.
+func (client *GraphQLClient) handleKeepAlive(handle *wsConnHandle) {
+	reply := client.protocol.keepAliveReply()
+	if reply == nil {
+		return
+	}
+	if err := handle.conn.WriteJSON(*reply); err != nil {
+		fmt.Println("Failed to send message:", err)
+	}
+}
+
+// handleConnectionError records the payload of a server-sent
+// connection_error message so it can be handed to an auth-error handler if
+// the connection is subsequently closed with an authentication close code.
+func (client *GraphQLClient) handleConnectionError(payload map[string]interface{}) {
+	fmt.Println("WebSocket connection error:", payload)
 	client.mu.Lock()
-	sub, ok := client.subs[subID]
+	client.lastErrorPayload = payload
 	client.mu.Unlock()
+}
+
+func (client *GraphQLClient) handleDataMessage(handle *wsConnHandle, result WebSocketMessage) {
+	subID := result.ID
+	handle.mu.Lock()
+	sub, ok := handle.subs[subID]
+	handle.mu.Unlock()
 	if !ok {
 		fmt.Println("Subscription not found for ID:", subID)
 		return
 	}
 
+	if client.protocol.isError(result.Type) {
+		sub.ErrChan <- &SubscriptionError{OperationID: subID, Errors: decodeGraphQLErrors(result.Payload)}
+		return
+	}
+
+	if errs := decodeGraphQLErrors(result.Payload["errors"]); len(errs) > 0 {
+		sub.ErrChan <- &SubscriptionError{OperationID: subID, Errors: errs}
+	}
+
+	rawData, hasData := result.Payload["data"]
+	if !hasData {
+		return
+	}
+
 	target := sub.NewTarget()
-	jsonData, err := json.Marshal(payload)
+	jsonData, err := json.Marshal(rawData)
 	if err != nil {
 		fmt.Println("Error serializing payload:", err)
 		return
@@ -186,205 +428,346 @@ func (client *GraphQLClient) handleDataMessage(result WebSocketMessage) {
 	sub.Channel <- target
 }
 
-func (client *GraphQLClient) handleCompleteMessage(subID string) {
+func (client *GraphQLClient) handleCompleteMessage(handle *wsConnHandle, subID string) {
 	fmt.Println("Subscription completed")
 	client.mu.Lock()
-	if sub, ok := client.subs[subID]; ok {
-		close(sub.Channel)
-		delete(client.subs, subID)
-	}
-	shouldClose := len(client.subs) == 0
+	delete(client.subIndex, subID)
 	client.mu.Unlock()
 
-	if shouldClose {
-		client.closeWebSocket()
+	handle.mu.Lock()
+	if sub, ok := handle.subs[subID]; ok {
+		close(sub.Channel)
+		close(sub.ErrChan)
+		delete(handle.subs, subID)
 	}
-}
+	empty := len(handle.subs) == 0
+	handle.mu.Unlock()
 
-func (client *GraphQLClient) sendPong() {
-	client.mu.Lock()
-	conn := client.wsConn
-	client.mu.Unlock()
-
-	pongMessage := WebSocketMessage{
-		Type: "pong",
-	}
-	if err := conn.WriteJSON(pongMessage); err != nil {
-		fmt.Println("Failed to send pong message:", err)
+	if empty {
+		client.scheduleIdleClose(handle)
 	}
 }
 
-func (client *GraphQLClient) reconnect() {
+// reconnectHandle retries the WebSocket connection that handle used with
+// exponential backoff after it drops unexpectedly, reusing the context of
+// the client's most recent connection attempt (on any of its connections)
+// so a caller cancelling that context can abort an in-progress reconnect
+// loop rather than waiting out WithMaxReconnectDuration.
+func (client *GraphQLClient) reconnectHandle(handle *wsConnHandle) {
+	client.setStatus(StatusReconnecting)
+
 	client.mu.Lock()
-	if client.wsConn != nil {
-		client.mu.Unlock()
-		return
-	}
+	ctx := client.connCtx
 	client.mu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-	for {
+	start := time.Now()
+	var lastErr error
+	for attempt := 0; ; attempt++ {
 		fmt.Println("Attempting to reconnect...")
-		if err := client.openWebSocket(); err == nil {
-			client.resubscribeAll()
+		newHandle, err := client.openHandle(ctx)
+		client.emitReconnectEvent(attempt, err)
+		if err == nil {
+			client.resubscribeHandle(handle, newHandle)
+			return
+		}
+		lastErr = err
+
+		if client.maxReconnectDuration > 0 && time.Since(start) > client.maxReconnectDuration {
+			client.emitGiveUp(lastErr)
+			client.setStatus(StatusDisconnected)
+			return
+		}
+
+		delay := client.backoff.duration(attempt)
+		fmt.Printf("Retrying in %v...\n", delay)
+		select {
+		case <-ctx.Done():
+			client.emitGiveUp(ctx.Err())
+			client.setStatus(StatusDisconnected)
 			return
+		case <-time.After(delay):
 		}
-		fmt.Printf("Retrying in %v...\n", retryInterval)
-		time.Sleep(retryInterval)
 	}
 }
 
-func (client *GraphQLClient) resubscribeAll() {
-	client.mu.Lock()
-	defer client.mu.Unlock()
-	for subID, sub := range client.subs {
-		startMessage := map[string]interface{}{
-			"id":   subID,
-			"type": "subscribe",
-			"payload": map[string]interface{}{
-				"query":     sub.Query,
-				"variables": sub.Variables,
-			},
-		}
-		if err := client.wsConn.WriteJSON(startMessage); err != nil {
+// resubscribeHandle replays every subscription that was live on old onto
+// the freshly (re)dialed newHandle.
+func (client *GraphQLClient) resubscribeHandle(old, newHandle *wsConnHandle) {
+	old.mu.Lock()
+	subs := old.subs
+	old.mu.Unlock()
+
+	for subID, sub := range subs {
+		client.mu.Lock()
+		client.subIndex[subID] = newHandle
+		client.mu.Unlock()
+
+		newHandle.mu.Lock()
+		newHandle.subs[subID] = sub
+		newHandle.mu.Unlock()
+
+		startMessage := client.protocol.subscribeMessage(subID, sub.Query, sub.Variables)
+		if err := newHandle.conn.WriteJSON(startMessage); err != nil {
 			fmt.Printf("Failed to resubscribe to %s: %v\n", subID, err)
 			close(sub.Channel)
-			delete(client.subs, subID)
+			close(sub.ErrChan)
+			client.cleanupSubscription(newHandle, subID)
 		}
 	}
 }
 
-func (client *GraphQLClient) closeWebSocket() {
+// removeHandle drops handle from the client's live connection set.
+func (client *GraphQLClient) removeHandle(handle *wsConnHandle) {
 	client.mu.Lock()
-	defer client.wg.Wait()
 	defer client.mu.Unlock()
-	if client.wsConn != nil {
-		closeMessage := map[string]interface{}{
-			"type": "connection_terminate",
-		}
-		if err := client.wsConn.WriteJSON(closeMessage); err != nil {
-			fmt.Println("Failed to send close message:", err)
+	for i, h := range client.handles {
+		if h == handle {
+			client.handles = append(client.handles[:i], client.handles[i+1:]...)
+			return
 		}
-		if err := client.wsConn.Close(); err != nil {
-			fmt.Println("Failed to close WebSocket connection:", err)
+	}
+}
+
+func (client *GraphQLClient) closeHandle(handle *wsConnHandle) {
+	handle.mu.Lock()
+	handle.closing = true
+	if handle.idleTimer != nil {
+		handle.idleTimer.Stop()
+		handle.idleTimer = nil
+	}
+	handle.mu.Unlock()
+
+	closeMessage := map[string]interface{}{
+		"type": "connection_terminate",
+	}
+	if err := handle.conn.WriteJSON(closeMessage); err != nil {
+		fmt.Println("Failed to send close message:", err)
+	}
+	if err := handle.conn.Close(); err != nil {
+		fmt.Println("Failed to close WebSocket connection:", err)
+	}
+	fmt.Println("WebSocket connection closed")
+}
+
+// scheduleIdleClose is called whenever handle's last subscription goes
+// away. With WithIdleCloseAfter unset (the default) the connection is torn
+// down immediately, matching the client's original single-connection
+// behavior; otherwise it's kept open for the grace period in case
+// pickHandle can hand it to a new subscription before the timer fires.
+func (client *GraphQLClient) scheduleIdleClose(handle *wsConnHandle) {
+	client.mu.Lock()
+	delay := client.idleCloseAfter
+	client.mu.Unlock()
+
+	if delay <= 0 {
+		client.removeHandle(handle)
+		client.closeHandle(handle)
+		return
+	}
+
+	handle.mu.Lock()
+	handle.idleTimer = time.AfterFunc(delay, func() {
+		handle.mu.Lock()
+		empty := len(handle.subs) == 0
+		handle.mu.Unlock()
+		if !empty {
+			return
 		}
-		client.wsConn = nil
-		fmt.Println("WebSocket connection closed")
+		client.removeHandle(handle)
+		client.closeHandle(handle)
+	})
+	handle.mu.Unlock()
+}
+
+// cancelIdleClose stops a pending idle-close timer on handle, used when
+// pickHandle decides to reuse a connection that was about to be torn down.
+func (client *GraphQLClient) cancelIdleClose(handle *wsConnHandle) {
+	handle.mu.Lock()
+	if handle.idleTimer != nil {
+		handle.idleTimer.Stop()
+		handle.idleTimer = nil
 	}
+	handle.mu.Unlock()
 }
 
 func (client *GraphQLClient) generateUniqueID() string {
 	return strconv.FormatInt(atomic.AddInt64(&client.counter, 1), 10)
 }
 
-func (client *GraphQLClient) subscribe(operation string, variables map[string]interface{}, newTarget func() interface{}) (<-chan interface{}, func() error, error) {
+// pickHandle returns an existing, non-full WebSocket connection to
+// multiplex a new subscription onto, opening a new one if none exists yet
+// or every existing handle has reached WithMaxSubscriptionsPerConn. Once
+// WithConnectionPool's cap is reached, it instead packs the subscription
+// onto whichever existing connection currently holds the fewest, rather
+// than opening another. This is what gives WithConnectionPool/
+// WithAsyncEngine and WithMaxSubscriptionsPerConn real multi-connection
+// demuxing instead of a single upstream connection fanned out through an
+// epoll loop.
+func (client *GraphQLClient) pickHandle(ctx context.Context) (*wsConnHandle, error) {
 	client.mu.Lock()
-	if client.wsConn == nil {
-		client.mu.Unlock()
-		if err := client.openWebSocket(); err != nil {
-			return nil, nil, err
+	var candidate *wsConnHandle
+	for _, handle := range client.handles {
+		if !handle.full(client.maxSubsPerConn) {
+			candidate = handle
+			break
 		}
-		client.mu.Lock()
+	}
+	atCap := candidate == nil && client.connectionPool > 0 && len(client.handles) >= client.connectionPool
+	client.mu.Unlock()
+
+	if candidate != nil {
+		client.cancelIdleClose(candidate)
+		return candidate, nil
+	}
+	if atCap {
+		return client.leastLoadedHandle()
+	}
+	return client.openHandle(ctx)
+}
+
+func (client *GraphQLClient) leastLoadedHandle() (*wsConnHandle, error) {
+	client.mu.Lock()
+	handles := append([]*wsConnHandle(nil), client.handles...)
+	client.mu.Unlock()
+
+	var best *wsConnHandle
+	bestCount := -1
+	for _, handle := range handles {
+		handle.mu.Lock()
+		n := len(handle.subs)
+		handle.mu.Unlock()
+		if bestCount == -1 || n < bestCount {
+			best, bestCount = handle, n
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no WebSocket connection available")
+	}
+	return best, nil
+}
+
+func (client *GraphQLClient) subscribe(ctx context.Context, operation string, variables map[string]interface{}, newTarget func() interface{}) (<-chan interface{}, <-chan *SubscriptionError, func(), error) {
+	handle, err := client.pickHandle(ctx)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	subID := client.generateUniqueID()
 	subChan := make(chan interface{})
-	client.subs[subID] = Subscription{
+	errChan := make(chan *SubscriptionError, 1)
+	sub := subscription{
 		Channel:   subChan,
+		ErrChan:   errChan,
 		Query:     operation,
 		Variables: variables,
 		NewTarget: newTarget,
 	}
 
+	handle.mu.Lock()
+	handle.subs[subID] = sub
+	handle.mu.Unlock()
+	client.mu.Lock()
+	client.subIndex[subID] = handle
 	client.mu.Unlock()
 
-	if err := client.sendSubscribeMessage(subID, operation, variables); err != nil {
-		client.cleanupSubscription(subID)
-		return nil, nil, err
+	if err := client.sendSubscribeMessage(handle, subID, operation, variables); err != nil {
+		client.cleanupSubscription(handle, subID)
+		return nil, nil, nil, err
+	}
+
+	cancel := func() {
+		_ = client.unsubscribe(subID)
 	}
 
-	return subChan, func() error {
-		return client.unsubscribe(subID)
-	}, nil
+	return subChan, errChan, cancel, nil
 }
 
-func (client *GraphQLClient) sendSubscribeMessage(subID, operation string, variables map[string]interface{}) error {
-	startMessage := WebSocketMessage{
-		ID:   subID,
-		Type: "subscribe",
-		Payload: map[string]interface{}{
-			"query":     operation,
-			"variables": variables,
-		},
-	}
+func (client *GraphQLClient) sendSubscribeMessage(handle *wsConnHandle, subID, operation string, variables map[string]interface{}) error {
+	startMessage := client.protocol.subscribeMessage(subID, operation, variables)
 
-	if err := client.wsConn.WriteJSON(startMessage); err != nil {
+	if err := handle.conn.WriteJSON(startMessage); err != nil {
 		return fmt.Errorf("failed to send start message: %w", err)
 	}
 	return nil
 }
 
-func (client *GraphQLClient) cleanupSubscription(subID string) {
+func (client *GraphQLClient) cleanupSubscription(handle *wsConnHandle, subID string) {
+	handle.mu.Lock()
+	delete(handle.subs, subID)
+	empty := len(handle.subs) == 0
+	handle.mu.Unlock()
+
 	client.mu.Lock()
-	delete(client.subs, subID)
-	shouldClose := len(client.subs) == 0
+	delete(client.subIndex, subID)
 	client.mu.Unlock()
 
-	if shouldClose {
-		client.closeWebSocket()
+	if empty {
+		client.scheduleIdleClose(handle)
 	}
 }
 
+// unsubscribe always removes subID from the client's subscription registry
+// and closes its channels, regardless of whether the live "stop" message
+// could be sent upstream. Without this, cancelling a subscription while
+// its connection is mid-reconnect left it orphaned in the registry:
+// resubscribeHandle would later revive it, and the next message for that
+// ID would block forever on an unbuffered channel send with no receiver,
+// wedging every other subscription sharing that connection's read loop.
 func (client *GraphQLClient) unsubscribe(subID string) error {
 	client.mu.Lock()
-	conn := client.wsConn
+	handle, ok := client.subIndex[subID]
+	if ok {
+		delete(client.subIndex, subID)
+	}
 	client.mu.Unlock()
 
-	if conn == nil {
-		return fmt.Errorf("no active WebSocket connection")
+	if !ok {
+		return nil
 	}
 
-	stopMessage := WebSocketMessage{
-		ID:   subID,
-		Type: "complete",
+	handle.mu.Lock()
+	sub, ok := handle.subs[subID]
+	if ok {
+		delete(handle.subs, subID)
 	}
+	empty := len(handle.subs) == 0
+	handle.mu.Unlock()
 
-	fmt.Println("Unsubscribing from subscription:", subID)
-	if err := conn.WriteJSON(stopMessage); err != nil {
-		return fmt.Errorf("failed to send stop message: %w", err)
+	if !ok {
+		return nil
 	}
+	close(sub.Channel)
+	close(sub.ErrChan)
 
-	client.mu.Lock()
-	delete(client.subs, subID)
-	client.mu.Unlock()
+	fmt.Println("Unsubscribing from subscription:", subID)
+	stopMessage := client.protocol.completeMessage(subID)
+	err := handle.conn.WriteJSON(stopMessage)
 
+	if empty {
+		// Done after the stop message so a connection being torn down for
+		// idling out doesn't turn a normal unsubscribe into a spurious
+		// "failed to send stop message" error.
+		client.scheduleIdleClose(handle)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to send stop message: %w", err)
+	}
 	return nil
 }
 
 func (client *GraphQLClient) Close() {
 	client.mu.Lock()
-	if client.wsConn != nil {
-		client.mu.Unlock()
-		client.closeWebSocket()
-	} else {
-		client.mu.Unlock()
-	}
-}
+	handles := client.handles
+	client.handles = nil
+	client.mu.Unlock()
 
-func Subscribe[T interface{}](client *GraphQLClient, operation string, variables map[string]interface{}) (<-chan *GraphQLResponse[T], func() error, error) {
-	subChan, subId, err := client.subscribe(operation, variables, func() interface{} {
-		return new(GraphQLResponse[T])
-	})
-	if err != nil {
-		return nil, nil, err
+	for _, handle := range handles {
+		client.closeHandle(handle)
 	}
-	typedChan := make(chan *GraphQLResponse[T])
-	client.wg.Add(1)
-	go func() {
-		defer client.wg.Done()
-		defer close(typedChan)
-		for msg := range subChan {
-			typedChan <- msg.(*GraphQLResponse[T])
-		}
-	}()
-	return typedChan, subId, nil
+	client.wg.Wait()
+	client.setStatus(StatusDisconnected)
 }